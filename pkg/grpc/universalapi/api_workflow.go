@@ -16,9 +16,12 @@ package universalapi
 import (
 	"context"
 	"errors"
+	"time"
 	"unicode"
 
 	"github.com/microsoft/durabletask-go/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -68,6 +71,229 @@ func (a *UniversalAPI) GetWorkflowBeta1(ctx context.Context, in *runtimev1pb.Get
 	return res, nil
 }
 
+// WaitForWorkflowCompletionBeta1 is the API handler for blocking until a workflow instance completes
+func (a *UniversalAPI) WaitForWorkflowCompletionBeta1(ctx context.Context, in *runtimev1pb.WaitForWorkflowCompletionRequest) (*runtimev1pb.WaitForWorkflowCompletionResponse, error) {
+	if err := a.validateInstanceID(in.InstanceId, false /* isCreate */); err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.WaitForWorkflowCompletionResponse{}, err
+	}
+
+	if in.Timeout.AsDuration() <= 0 {
+		err := messages.ErrMissingWorkflowTimeout
+		a.Logger.Debug(err)
+		return &runtimev1pb.WaitForWorkflowCompletionResponse{}, err
+	}
+
+	// Workflow requires actors to be ready
+	a.WaitForActorsReady(ctx)
+
+	workflowComponent, err := a.getWorkflowComponent(in.WorkflowComponent)
+	if err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.WaitForWorkflowCompletionResponse{}, err
+	}
+
+	resultCh, err := workflowComponent.WaitForCompletion(ctx, in.InstanceId)
+	if err != nil {
+		if errors.Is(err, api.ErrInstanceNotFound) {
+			err = messages.ErrWorkflowInstanceNotFound.WithFormat(in.InstanceId, err)
+		} else {
+			err = messages.ErrWorkflowGetResponse.WithFormat(in.InstanceId, err)
+		}
+		a.Logger.Debug(err)
+		return &runtimev1pb.WaitForWorkflowCompletionResponse{}, err
+	}
+
+	timer := time.NewTimer(in.Timeout.AsDuration())
+	defer timer.Stop()
+
+	for {
+		select {
+		case result, ok := <-resultCh:
+			if !ok {
+				err := messages.ErrWorkflowGetResponse.WithFormat(in.InstanceId, errors.New("notification channel closed before a terminal state was reached"))
+				a.Logger.Debug(err)
+				return &runtimev1pb.WaitForWorkflowCompletionResponse{}, err
+			}
+			if !isTerminalWorkflowStatus(result.RuntimeStatus) {
+				continue
+			}
+			res := &runtimev1pb.WaitForWorkflowCompletionResponse{
+				RuntimeStatus:  result.RuntimeStatus,
+				Output:         result.Output,
+				FailureDetails: result.FailureDetails,
+				CompletedAt:    timestamppb.New(result.LastUpdatedAt),
+			}
+			return res, nil
+		case <-ctx.Done():
+			err := status.FromContextError(ctx.Err()).Err()
+			a.Logger.Debug(err)
+			return &runtimev1pb.WaitForWorkflowCompletionResponse{}, err
+		case <-timer.C:
+			current, getErr := workflowComponent.Get(ctx, &workflows.GetRequest{InstanceID: in.InstanceId})
+			res := &runtimev1pb.WaitForWorkflowCompletionResponse{}
+			if getErr == nil {
+				res.RuntimeStatus = current.Workflow.RuntimeStatus
+			}
+			err := status.Error(codes.DeadlineExceeded, messages.ErrWorkflowGetResponse.WithFormat(in.InstanceId, "timed out waiting for completion").Error())
+			a.Logger.Debug(err)
+			return res, err
+		}
+	}
+}
+
+// WatchWorkflowBeta1 is the API handler for streaming workflow status transitions to the caller
+func (a *UniversalAPI) WatchWorkflowBeta1(in *runtimev1pb.GetWorkflowRequest, stream runtimev1pb.Dapr_WatchWorkflowBeta1Server) error {
+	ctx := stream.Context()
+	if err := a.validateInstanceID(in.InstanceId, false /* isCreate */); err != nil {
+		a.Logger.Debug(err)
+		return err
+	}
+
+	// Workflow requires actors to be ready
+	a.WaitForActorsReady(ctx)
+
+	workflowComponent, err := a.getWorkflowComponent(in.WorkflowComponent)
+	if err != nil {
+		a.Logger.Debug(err)
+		return err
+	}
+
+	resultCh, err := workflowComponent.WaitForCompletion(ctx, in.InstanceId)
+	if err != nil {
+		if errors.Is(err, api.ErrInstanceNotFound) {
+			err = messages.ErrWorkflowInstanceNotFound.WithFormat(in.InstanceId, err)
+		} else {
+			err = messages.ErrWorkflowGetResponse.WithFormat(in.InstanceId, err)
+		}
+		a.Logger.Debug(err)
+		return err
+	}
+
+	for {
+		select {
+		case result, ok := <-resultCh:
+			if !ok {
+				return nil
+			}
+			res := &runtimev1pb.GetWorkflowResponse{
+				InstanceId:    in.InstanceId,
+				WorkflowName:  result.WorkflowName,
+				CreatedAt:     timestamppb.New(result.CreatedAt),
+				LastUpdatedAt: timestamppb.New(result.LastUpdatedAt),
+				RuntimeStatus: result.RuntimeStatus,
+				Properties:    result.Properties,
+			}
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+			if isTerminalWorkflowStatus(result.RuntimeStatus) {
+				return nil
+			}
+		case <-ctx.Done():
+			err := status.FromContextError(ctx.Err()).Err()
+			a.Logger.Debug(err)
+			return err
+		}
+	}
+}
+
+// defaultMaxGetWorkflowHistoryPageSize is the hardcoded per-page cap for GetWorkflowHistoryPageBeta1.
+const defaultMaxGetWorkflowHistoryPageSize = 1000
+
+// GetWorkflowHistoryBeta1 is the API handler for streaming a workflow instance's event history
+func (a *UniversalAPI) GetWorkflowHistoryBeta1(in *runtimev1pb.GetWorkflowHistoryRequest, stream runtimev1pb.Dapr_GetWorkflowHistoryBeta1Server) error {
+	ctx := stream.Context()
+	if err := a.validateInstanceID(in.InstanceId, false /* isCreate */); err != nil {
+		a.Logger.Debug(err)
+		return err
+	}
+
+	// Workflow requires actors to be ready
+	a.WaitForActorsReady(ctx)
+
+	workflowComponent, err := a.getWorkflowComponent(in.WorkflowComponent)
+	if err != nil {
+		a.Logger.Debug(err)
+		return err
+	}
+
+	req := workflows.GetHistoryRequest{
+		InstanceID:  in.InstanceId,
+		FromEventID: in.FromEventId,
+	}
+	it, err := workflowComponent.GetHistory(ctx, &req)
+	if err != nil {
+		if errors.Is(err, api.ErrInstanceNotFound) {
+			err = messages.ErrWorkflowInstanceNotFound.WithFormat(in.InstanceId, err)
+		} else {
+			err = messages.ErrWorkflowGetResponse.WithFormat(in.InstanceId, err)
+		}
+		a.Logger.Debug(err)
+		return err
+	}
+	defer it.Close()
+
+	for it.Next(ctx) {
+		if err := stream.Send(toHistoryEventProto(it.Event())); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// GetWorkflowHistoryPageBeta1 is the API handler for paginating through a workflow instance's event history
+func (a *UniversalAPI) GetWorkflowHistoryPageBeta1(ctx context.Context, in *runtimev1pb.GetWorkflowHistoryRequest) (*runtimev1pb.GetWorkflowHistoryPageResponse, error) {
+	if err := a.validateInstanceID(in.InstanceId, false /* isCreate */); err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.GetWorkflowHistoryPageResponse{}, err
+	}
+
+	// Workflow requires actors to be ready
+	a.WaitForActorsReady(ctx)
+
+	workflowComponent, err := a.getWorkflowComponent(in.WorkflowComponent)
+	if err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.GetWorkflowHistoryPageResponse{}, err
+	}
+
+	req := workflows.GetHistoryRequest{
+		InstanceID:  in.InstanceId,
+		FromEventID: in.FromEventId,
+	}
+	it, err := workflowComponent.GetHistory(ctx, &req)
+	if err != nil {
+		if errors.Is(err, api.ErrInstanceNotFound) {
+			err = messages.ErrWorkflowInstanceNotFound.WithFormat(in.InstanceId, err)
+		} else {
+			err = messages.ErrWorkflowGetResponse.WithFormat(in.InstanceId, err)
+		}
+		a.Logger.Debug(err)
+		return &runtimev1pb.GetWorkflowHistoryPageResponse{}, err
+	}
+	defer it.Close()
+
+	events := make([]*runtimev1pb.HistoryEvent, 0, defaultMaxGetWorkflowHistoryPageSize)
+	nextFromEventID := in.FromEventId
+	for len(events) < defaultMaxGetWorkflowHistoryPageSize && it.Next(ctx) {
+		event := it.Event()
+		events = append(events, toHistoryEventProto(event))
+		nextFromEventID = event.EventID + 1
+	}
+	if err := it.Err(); err != nil {
+		err = messages.ErrWorkflowGetResponse.WithFormat(in.InstanceId, err)
+		a.Logger.Debug(err)
+		return &runtimev1pb.GetWorkflowHistoryPageResponse{}, err
+	}
+
+	res := &runtimev1pb.GetWorkflowHistoryPageResponse{
+		Events:          events,
+		NextFromEventId: nextFromEventID,
+	}
+	return res, nil
+}
+
 // StartWorkflowBeta1 is the API handler for starting a workflow
 func (a *UniversalAPI) StartWorkflowBeta1(ctx context.Context, in *runtimev1pb.StartWorkflowRequest) (*runtimev1pb.StartWorkflowResponse, error) {
 	if err := a.validateInstanceID(in.InstanceId, true /* isCreate */); err != nil {
@@ -109,6 +335,62 @@ func (a *UniversalAPI) StartWorkflowBeta1(ctx context.Context, in *runtimev1pb.S
 	return ret, nil
 }
 
+// SignalWithStartWorkflowBeta1 is the API handler for atomically signaling or starting a workflow
+func (a *UniversalAPI) SignalWithStartWorkflowBeta1(ctx context.Context, in *runtimev1pb.SignalWithStartWorkflowRequest) (*runtimev1pb.SignalWithStartWorkflowResponse, error) {
+	if err := a.validateInstanceID(in.InstanceId, true /* isCreate */); err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.SignalWithStartWorkflowResponse{}, err
+	}
+
+	if in.WorkflowName == "" {
+		err := messages.ErrWorkflowNameMissing
+		a.Logger.Debug(err)
+		return &runtimev1pb.SignalWithStartWorkflowResponse{}, err
+	}
+
+	if in.EventName == "" {
+		err := messages.ErrMissingWorkflowEventName
+		a.Logger.Debug(err)
+		return &runtimev1pb.SignalWithStartWorkflowResponse{}, err
+	}
+
+	// Workflow requires actors to be ready
+	a.WaitForActorsReady(ctx)
+
+	workflowComponent, err := a.getWorkflowComponent(in.WorkflowComponent)
+	if err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.SignalWithStartWorkflowResponse{}, err
+	}
+
+	req := workflows.SignalWithStartRequest{
+		InstanceID:    in.InstanceId,
+		Options:       in.Options,
+		WorkflowName:  in.WorkflowName,
+		WorkflowInput: in.Input,
+		EventName:     in.EventName,
+		EventData:     in.EventData,
+	}
+
+	resp, err := workflowComponent.SignalWithStart(ctx, &req)
+	if err != nil {
+		// SignalWithStart fails in one of two distinct phases: starting a brand-new instance, or
+		// appending the signal to one that already existed. Only the former is a "start" failure.
+		if errors.Is(err, workflows.ErrSignalAppendFailed) {
+			err = messages.ErrRaiseEventWorkflow.WithFormat(in.InstanceId, err)
+		} else {
+			err = messages.ErrStartWorkflow.WithFormat(in.WorkflowName, err)
+		}
+		a.Logger.Debug(err)
+		return &runtimev1pb.SignalWithStartWorkflowResponse{}, err
+	}
+	ret := &runtimev1pb.SignalWithStartWorkflowResponse{
+		InstanceId: resp.InstanceID,
+		StartedNew: resp.StartedNew,
+	}
+	return ret, nil
+}
+
 // TerminateWorkflowBeta1 is the API handler for terminating a workflow
 func (a *UniversalAPI) TerminateWorkflowBeta1(ctx context.Context, in *runtimev1pb.TerminateWorkflowRequest) (*emptypb.Empty, error) {
 	emptyResponse := &emptypb.Empty{}
@@ -269,6 +551,162 @@ func (a *UniversalAPI) PurgeWorkflowBeta1(ctx context.Context, in *runtimev1pb.P
 	return emptyResponse, nil
 }
 
+// ResetWorkflowBeta1 is the API handler for rewinding a workflow instance to a prior history point
+func (a *UniversalAPI) ResetWorkflowBeta1(ctx context.Context, in *runtimev1pb.ResetWorkflowRequest) (*runtimev1pb.ResetWorkflowResponse, error) {
+	if err := a.validateInstanceID(in.InstanceId, false /* isCreate */); err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.ResetWorkflowResponse{}, err
+	}
+
+	req := workflows.ResetRequest{
+		InstanceID:     in.InstanceId,
+		Reason:         in.Reason,
+		ReapplySignals: in.ReapplySignals,
+	}
+
+	// reset_to_event_id and reset_to_timestamp are a oneof: unlike a bare int64 field, this lets a caller
+	// target event ID 0 (the OrchestrationStarted event) without it being indistinguishable from "unset".
+	switch resetPoint := in.GetResetPoint().(type) {
+	case *runtimev1pb.ResetWorkflowRequest_ResetToEventId:
+		eventID := resetPoint.ResetToEventId
+		req.ResetToEventID = &eventID
+	case *runtimev1pb.ResetWorkflowRequest_ResetToTimestamp:
+		ts := resetPoint.ResetToTimestamp.AsTime()
+		req.ResetToTimestamp = &ts
+	default:
+		err := messages.ErrInvalidResetPoint.WithFormat(in.InstanceId)
+		a.Logger.Debug(err)
+		return &runtimev1pb.ResetWorkflowResponse{}, err
+	}
+
+	// Workflow requires actors to be ready
+	a.WaitForActorsReady(ctx)
+
+	workflowComponent, err := a.getWorkflowComponent(in.WorkflowComponent)
+	if err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.ResetWorkflowResponse{}, err
+	}
+
+	resp, err := workflowComponent.Reset(ctx, &req)
+	if err != nil {
+		if errors.Is(err, api.ErrInstanceNotFound) {
+			err = messages.ErrWorkflowInstanceNotFound.WithFormat(in.InstanceId, err)
+		} else if errors.Is(err, workflows.ErrInvalidResetPoint) {
+			err = messages.ErrInvalidResetPoint.WithFormat(in.InstanceId)
+		} else {
+			err = messages.ErrResetWorkflow.WithFormat(in.InstanceId, err)
+		}
+		a.Logger.Debug(err)
+		return &runtimev1pb.ResetWorkflowResponse{}, err
+	}
+
+	res := &runtimev1pb.ResetWorkflowResponse{
+		NewInstanceId: resp.NewInstanceID,
+	}
+	return res, nil
+}
+
+// defaultMaxListWorkflowsPageSize is the hardcoded cap on page_size for ListWorkflowsBeta1, also used
+// when page_size is unset or negative. It is not operator-configurable.
+const defaultMaxListWorkflowsPageSize = 1000
+
+// ListWorkflowsBeta1 is the API handler for enumerating workflow instances with filtering and pagination
+func (a *UniversalAPI) ListWorkflowsBeta1(ctx context.Context, in *runtimev1pb.ListWorkflowsRequest) (*runtimev1pb.ListWorkflowsResponse, error) {
+	// Workflow requires actors to be ready
+	a.WaitForActorsReady(ctx)
+
+	workflowComponent, err := a.getWorkflowComponent(in.WorkflowComponent)
+	if err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.ListWorkflowsResponse{}, err
+	}
+
+	pageSize := in.PageSize
+	if pageSize <= 0 || pageSize > defaultMaxListWorkflowsPageSize {
+		pageSize = defaultMaxListWorkflowsPageSize
+	}
+
+	req := workflows.ListRequest{
+		WorkflowName:  in.WorkflowName,
+		RuntimeStatus: in.RuntimeStatus,
+		CreatedAfter:  in.CreatedAfter.AsTime(),
+		CreatedBefore: in.CreatedBefore.AsTime(),
+		PageSize:      int(pageSize),
+		PageToken:     in.PageToken,
+	}
+
+	response, err := workflowComponent.List(ctx, &req)
+	if err != nil {
+		err = messages.ErrListWorkflows.WithFormat(in.WorkflowComponent, err)
+		a.Logger.Debug(err)
+		return &runtimev1pb.ListWorkflowsResponse{}, err
+	}
+
+	instances := make([]*runtimev1pb.WorkflowInstanceSummary, len(response.Instances))
+	for i, instance := range response.Instances {
+		instances[i] = &runtimev1pb.WorkflowInstanceSummary{
+			InstanceId:    instance.InstanceID,
+			WorkflowName:  instance.WorkflowName,
+			CreatedAt:     timestamppb.New(instance.CreatedAt),
+			LastUpdatedAt: timestamppb.New(instance.LastUpdatedAt),
+			RuntimeStatus: instance.RuntimeStatus,
+		}
+	}
+
+	res := &runtimev1pb.ListWorkflowsResponse{
+		Instances:     instances,
+		NextPageToken: response.NextPageToken,
+	}
+	return res, nil
+}
+
+// QueryWorkflowBeta1 is the API handler for synchronously querying a running workflow instance for derived state
+func (a *UniversalAPI) QueryWorkflowBeta1(ctx context.Context, in *runtimev1pb.QueryWorkflowRequest) (*runtimev1pb.QueryWorkflowResponse, error) {
+	if err := a.validateInstanceID(in.InstanceId, false /* isCreate */); err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.QueryWorkflowResponse{}, err
+	}
+
+	if in.QueryName == "" {
+		err := messages.ErrMissingWorkflowQueryName
+		a.Logger.Debug(err)
+		return &runtimev1pb.QueryWorkflowResponse{}, err
+	}
+
+	// Workflow requires actors to be ready
+	a.WaitForActorsReady(ctx)
+
+	workflowComponent, err := a.getWorkflowComponent(in.WorkflowComponent)
+	if err != nil {
+		a.Logger.Debug(err)
+		return &runtimev1pb.QueryWorkflowResponse{}, err
+	}
+
+	req := workflows.QueryRequest{
+		InstanceID: in.InstanceId,
+		QueryName:  in.QueryName,
+		QueryData:  in.QueryData,
+	}
+
+	response, err := workflowComponent.Query(ctx, &req)
+	if err != nil {
+		if errors.Is(err, api.ErrInstanceNotFound) {
+			err = messages.ErrWorkflowInstanceNotFound.WithFormat(in.InstanceId, err)
+		} else {
+			err = messages.ErrQueryWorkflow.WithFormat(in.InstanceId, err)
+		}
+		a.Logger.Debug(err)
+		return &runtimev1pb.QueryWorkflowResponse{}, err
+	}
+
+	res := &runtimev1pb.QueryWorkflowResponse{
+		Status:      response.Status,
+		QueryResult: response.QueryResult,
+	}
+	return res, nil
+}
+
 // GetWorkflowAlpha1 is the API handler for getting workflow details
 func (a *UniversalAPI) GetWorkflowAlpha1(ctx context.Context, in *runtimev1pb.GetWorkflowRequest) (*runtimev1pb.GetWorkflowResponse, error) {
 	return a.GetWorkflowBeta1(ctx, in)
@@ -304,6 +742,11 @@ func (a *UniversalAPI) PurgeWorkflowAlpha1(ctx context.Context, in *runtimev1pb.
 	return a.PurgeWorkflowBeta1(ctx, in)
 }
 
+// QueryWorkflowAlpha1 is the API handler for synchronously querying a running workflow instance for derived state
+func (a *UniversalAPI) QueryWorkflowAlpha1(ctx context.Context, in *runtimev1pb.QueryWorkflowRequest) (*runtimev1pb.QueryWorkflowResponse, error) {
+	return a.QueryWorkflowBeta1(ctx, in)
+}
+
 func (a *UniversalAPI) validateInstanceID(instanceID string, isCreate bool) error {
 	if instanceID == "" {
 		return messages.ErrMissingOrEmptyInstance
@@ -340,3 +783,90 @@ func (a *UniversalAPI) getWorkflowComponent(componentName string) (workflows.Wor
 	}
 	return workflowComponent, nil
 }
+
+// isTerminalWorkflowStatus reports whether a runtime status represents a completed orchestration run,
+// i.e. one that will never transition again.
+func isTerminalWorkflowStatus(status runtimev1pb.WorkflowRuntimeStatus) bool {
+	switch status {
+	case runtimev1pb.WorkflowRuntimeStatus_COMPLETED,
+		runtimev1pb.WorkflowRuntimeStatus_FAILED,
+		runtimev1pb.WorkflowRuntimeStatus_TERMINATED:
+		return true
+	default:
+		return false
+	}
+}
+
+// toHistoryEventProto translates a components-contrib workflow history event into the Dapr-facing
+// proto representation, so that the durabletask history format doesn't leak past this package.
+func toHistoryEventProto(event *workflows.HistoryEvent) *runtimev1pb.HistoryEvent {
+	res := &runtimev1pb.HistoryEvent{
+		EventId:   event.EventID,
+		Timestamp: timestamppb.New(event.Timestamp),
+		EventType: event.EventType,
+	}
+
+	switch event.EventType {
+	case runtimev1pb.HistoryEventType_ORCHESTRATION_STARTED:
+		res.Payload = &runtimev1pb.HistoryEvent_OrchestrationStarted{
+			OrchestrationStarted: &runtimev1pb.OrchestrationStartedEvent{
+				WorkflowName: event.WorkflowName,
+				Input:        event.WorkflowInput,
+			},
+		}
+	case runtimev1pb.HistoryEventType_TASK_SCHEDULED:
+		res.Payload = &runtimev1pb.HistoryEvent_TaskScheduled{
+			TaskScheduled: &runtimev1pb.TaskScheduledEvent{
+				Name:  event.TaskName,
+				Input: event.TaskInput,
+			},
+		}
+	case runtimev1pb.HistoryEventType_TASK_COMPLETED:
+		res.Payload = &runtimev1pb.HistoryEvent_TaskCompleted{
+			TaskCompleted: &runtimev1pb.TaskCompletedEvent{
+				Result: event.TaskResult,
+			},
+		}
+	case runtimev1pb.HistoryEventType_TASK_FAILED:
+		res.Payload = &runtimev1pb.HistoryEvent_TaskFailed{
+			TaskFailed: &runtimev1pb.TaskFailedEvent{
+				FailureDetails: event.FailureDetails,
+			},
+		}
+	case runtimev1pb.HistoryEventType_TIMER_CREATED:
+		res.Payload = &runtimev1pb.HistoryEvent_TimerCreated{
+			TimerCreated: &runtimev1pb.TimerCreatedEvent{
+				FireAt: timestamppb.New(event.FireAt),
+			},
+		}
+	case runtimev1pb.HistoryEventType_TIMER_FIRED:
+		res.Payload = &runtimev1pb.HistoryEvent_TimerFired{
+			TimerFired: &runtimev1pb.TimerFiredEvent{
+				FireAt: timestamppb.New(event.FireAt),
+			},
+		}
+	case runtimev1pb.HistoryEventType_EVENT_RAISED:
+		res.Payload = &runtimev1pb.HistoryEvent_EventRaised{
+			EventRaised: &runtimev1pb.EventRaisedEvent{
+				Name:  event.EventName,
+				Input: event.EventData,
+			},
+		}
+	case runtimev1pb.HistoryEventType_SUB_ORCHESTRATION_INSTANCE_CREATED:
+		res.Payload = &runtimev1pb.HistoryEvent_SubOrchestrationInstanceCreated{
+			SubOrchestrationInstanceCreated: &runtimev1pb.SubOrchestrationInstanceCreatedEvent{
+				Name:       event.TaskName,
+				InstanceId: event.SubOrchestrationInstanceID,
+				Input:      event.TaskInput,
+			},
+		}
+	case runtimev1pb.HistoryEventType_ORCHESTRATION_COMPLETED, runtimev1pb.HistoryEventType_ORCHESTRATION_TERMINATED:
+		res.Payload = &runtimev1pb.HistoryEvent_OrchestrationCompleted{
+			OrchestrationCompleted: &runtimev1pb.OrchestrationCompletedEvent{
+				Output:         event.TaskResult,
+				FailureDetails: event.FailureDetails,
+			},
+		}
+	}
+	return res
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package universalapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/microsoft/durabletask-go/api"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dapr/components-contrib/workflows"
+	"github.com/dapr/dapr/pkg/messages"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	"github.com/dapr/kit/logger"
+)
+
+// fakeWorkflowComponent is a workflows.Workflow test double that only overrides the methods a given
+// test exercises; every other call panics via the embedded nil interface.
+type fakeWorkflowComponent struct {
+	workflows.Workflow
+	signalWithStartFn func(ctx context.Context, req *workflows.SignalWithStartRequest) (*workflows.SignalWithStartResponse, error)
+	getHistoryFn      func(ctx context.Context, req *workflows.GetHistoryRequest) (workflows.HistoryIterator, error)
+	queryFn           func(ctx context.Context, req *workflows.QueryRequest) (*workflows.QueryResponse, error)
+	listFn            func(ctx context.Context, req *workflows.ListRequest) (*workflows.ListResponse, error)
+	resetFn           func(ctx context.Context, req *workflows.ResetRequest) (*workflows.ResetResponse, error)
+}
+
+func (f *fakeWorkflowComponent) SignalWithStart(ctx context.Context, req *workflows.SignalWithStartRequest) (*workflows.SignalWithStartResponse, error) {
+	return f.signalWithStartFn(ctx, req)
+}
+
+// fakeHistoryIterator replays a fixed slice of history events.
+type fakeHistoryIterator struct {
+	events []*workflows.HistoryEvent
+	idx    int
+}
+
+func (f *fakeHistoryIterator) Next(ctx context.Context) bool {
+	if f.idx >= len(f.events) {
+		return false
+	}
+	f.idx++
+	return true
+}
+
+func (f *fakeHistoryIterator) Event() *workflows.HistoryEvent { return f.events[f.idx-1] }
+func (f *fakeHistoryIterator) Err() error                     { return nil }
+func (f *fakeHistoryIterator) Close()                         {}
+
+func (f *fakeWorkflowComponent) GetHistory(ctx context.Context, req *workflows.GetHistoryRequest) (workflows.HistoryIterator, error) {
+	return f.getHistoryFn(ctx, req)
+}
+
+func (f *fakeWorkflowComponent) Query(ctx context.Context, req *workflows.QueryRequest) (*workflows.QueryResponse, error) {
+	return f.queryFn(ctx, req)
+}
+
+func (f *fakeWorkflowComponent) List(ctx context.Context, req *workflows.ListRequest) (*workflows.ListResponse, error) {
+	return f.listFn(ctx, req)
+}
+
+func (f *fakeWorkflowComponent) Reset(ctx context.Context, req *workflows.ResetRequest) (*workflows.ResetResponse, error) {
+	return f.resetFn(ctx, req)
+}
+
+func newTestUniversalAPI(t *testing.T) (*UniversalAPI, *compstore.ComponentStore) {
+	t.Helper()
+	compStore := compstore.New()
+	return &UniversalAPI{
+		Logger:    logger.NewLogger("dapr.test"),
+		CompStore: compStore,
+	}, compStore
+}
+
+func TestSignalWithStartWorkflowBeta1_SignalFailureIsNotReportedAsStartFailure(t *testing.T) {
+	startErr := errors.New("actor activation failed")
+	signalErr := workflows.ErrSignalAppendFailed
+
+	tests := []struct {
+		name       string
+		instanceID string
+		wantErr    error
+	}{
+		{
+			name:       "new instance fails to start",
+			instanceID: "fresh-instance",
+			wantErr:    messages.ErrStartWorkflow.WithFormat("wf", startErr),
+		},
+		{
+			name:       "existing instance fails to receive the signal",
+			instanceID: "existing-instance",
+			wantErr:    messages.ErrRaiseEventWorkflow.WithFormat("existing-instance", signalErr),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, compStore := newTestUniversalAPI(t)
+			compStore.AddWorkflow("dapr", &fakeWorkflowComponent{
+				signalWithStartFn: func(ctx context.Context, req *workflows.SignalWithStartRequest) (*workflows.SignalWithStartResponse, error) {
+					if req.InstanceID == "fresh-instance" {
+						return nil, startErr
+					}
+					return nil, signalErr
+				},
+			})
+
+			_, err := a.SignalWithStartWorkflowBeta1(context.Background(), &runtimev1pb.SignalWithStartWorkflowRequest{
+				InstanceId:        tt.instanceID,
+				WorkflowComponent: "dapr",
+				WorkflowName:      "wf",
+				EventName:         "go",
+			})
+
+			require.Error(t, err)
+			require.Equal(t, tt.wantErr.Error(), err.Error())
+		})
+	}
+}
+
+func TestWaitForWorkflowCompletionBeta1_RequiresPositiveTimeout(t *testing.T) {
+	a, _ := newTestUniversalAPI(t)
+
+	tests := []struct {
+		name    string
+		timeout *durationpb.Duration
+	}{
+		{name: "timeout not set"},
+		{name: "zero timeout", timeout: durationpb.New(0)},
+		{name: "negative timeout", timeout: durationpb.New(-time.Second)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := a.WaitForWorkflowCompletionBeta1(context.Background(), &runtimev1pb.WaitForWorkflowCompletionRequest{
+				InstanceId:        "instance1",
+				WorkflowComponent: "dapr",
+				Timeout:           tt.timeout,
+			})
+			require.ErrorIs(t, err, messages.ErrMissingWorkflowTimeout)
+		})
+	}
+}
+
+func TestGetWorkflowHistoryPageBeta1_NextFromEventIdFollowsLastReturnedEvent(t *testing.T) {
+	a, compStore := newTestUniversalAPI(t)
+
+	events := []*workflows.HistoryEvent{
+		{EventID: 5, EventType: runtimev1pb.HistoryEventType_TASK_SCHEDULED},
+		{EventID: 6, EventType: runtimev1pb.HistoryEventType_TASK_COMPLETED},
+		{EventID: 7, EventType: runtimev1pb.HistoryEventType_EVENT_RAISED},
+	}
+	compStore.AddWorkflow("dapr", &fakeWorkflowComponent{
+		getHistoryFn: func(ctx context.Context, req *workflows.GetHistoryRequest) (workflows.HistoryIterator, error) {
+			require.Equal(t, int64(5), req.FromEventID)
+			return &fakeHistoryIterator{events: events}, nil
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := a.GetWorkflowHistoryPageBeta1(ctx, &runtimev1pb.GetWorkflowHistoryRequest{
+		InstanceId:        "instance1",
+		WorkflowComponent: "dapr",
+		FromEventId:       5,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, res.Events, len(events))
+	require.Equal(t, int64(8), res.NextFromEventId)
+}
+
+func TestGetWorkflowHistoryPageBeta1_NextFromEventIdUnchangedOnEmptyPage(t *testing.T) {
+	a, compStore := newTestUniversalAPI(t)
+
+	compStore.AddWorkflow("dapr", &fakeWorkflowComponent{
+		getHistoryFn: func(ctx context.Context, req *workflows.GetHistoryRequest) (workflows.HistoryIterator, error) {
+			return &fakeHistoryIterator{}, nil
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := a.GetWorkflowHistoryPageBeta1(ctx, &runtimev1pb.GetWorkflowHistoryRequest{
+		InstanceId:        "instance1",
+		WorkflowComponent: "dapr",
+		FromEventId:       5,
+	})
+
+	require.NoError(t, err)
+	require.Empty(t, res.Events)
+	require.Equal(t, int64(5), res.NextFromEventId)
+}
+
+func TestQueryWorkflowBeta1(t *testing.T) {
+	t.Run("missing query name is rejected before reaching the component", func(t *testing.T) {
+		a, compStore := newTestUniversalAPI(t)
+		compStore.AddWorkflow("dapr", &fakeWorkflowComponent{
+			queryFn: func(ctx context.Context, req *workflows.QueryRequest) (*workflows.QueryResponse, error) {
+				t.Fatal("Query should not be called when query_name is missing")
+				return nil, nil
+			},
+		})
+
+		_, err := a.QueryWorkflowBeta1(context.Background(), &runtimev1pb.QueryWorkflowRequest{
+			InstanceId:        "instance1",
+			WorkflowComponent: "dapr",
+		})
+		require.ErrorIs(t, err, messages.ErrMissingWorkflowQueryName)
+	})
+
+	t.Run("instance not found is mapped to the shared not-found error", func(t *testing.T) {
+		a, compStore := newTestUniversalAPI(t)
+		compStore.AddWorkflow("dapr", &fakeWorkflowComponent{
+			queryFn: func(ctx context.Context, req *workflows.QueryRequest) (*workflows.QueryResponse, error) {
+				return nil, api.ErrInstanceNotFound
+			},
+		})
+
+		_, err := a.QueryWorkflowBeta1(context.Background(), &runtimev1pb.QueryWorkflowRequest{
+			InstanceId:        "missing-instance",
+			WorkflowComponent: "dapr",
+			QueryName:         "currentStep",
+		})
+		require.Equal(t, messages.ErrWorkflowInstanceNotFound.WithFormat("missing-instance", api.ErrInstanceNotFound).Error(), err.Error())
+	})
+
+	t.Run("query result and status are passed through on success", func(t *testing.T) {
+		a, compStore := newTestUniversalAPI(t)
+		compStore.AddWorkflow("dapr", &fakeWorkflowComponent{
+			queryFn: func(ctx context.Context, req *workflows.QueryRequest) (*workflows.QueryResponse, error) {
+				require.Equal(t, "currentStep", req.QueryName)
+				return &workflows.QueryResponse{
+					Status:      runtimev1pb.QueryWorkflowResponse_OK,
+					QueryResult: []byte("step-3"),
+				}, nil
+			},
+		})
+
+		res, err := a.QueryWorkflowBeta1(context.Background(), &runtimev1pb.QueryWorkflowRequest{
+			InstanceId:        "instance1",
+			WorkflowComponent: "dapr",
+			QueryName:         "currentStep",
+		})
+		require.NoError(t, err)
+		require.Equal(t, runtimev1pb.QueryWorkflowResponse_OK, res.Status)
+		require.Equal(t, []byte("step-3"), res.QueryResult)
+	})
+}
+
+func TestListWorkflowsBeta1_PageSizeClamping(t *testing.T) {
+	tests := []struct {
+		name         string
+		requested    int32
+		wantPageSize int
+	}{
+		{name: "unset page size falls back to the default cap", requested: 0, wantPageSize: defaultMaxListWorkflowsPageSize},
+		{name: "negative page size falls back to the default cap", requested: -1, wantPageSize: defaultMaxListWorkflowsPageSize},
+		{name: "oversized page size is clamped to the default cap", requested: defaultMaxListWorkflowsPageSize + 1, wantPageSize: defaultMaxListWorkflowsPageSize},
+		{name: "in-range page size is passed through unchanged", requested: 25, wantPageSize: 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, compStore := newTestUniversalAPI(t)
+			var gotPageSize int
+			compStore.AddWorkflow("dapr", &fakeWorkflowComponent{
+				listFn: func(ctx context.Context, req *workflows.ListRequest) (*workflows.ListResponse, error) {
+					gotPageSize = req.PageSize
+					return &workflows.ListResponse{}, nil
+				},
+			})
+
+			_, err := a.ListWorkflowsBeta1(context.Background(), &runtimev1pb.ListWorkflowsRequest{
+				WorkflowComponent: "dapr",
+				PageSize:          tt.requested,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantPageSize, gotPageSize)
+		})
+	}
+}
+
+func TestResetWorkflowBeta1_ResolvesResetPointOneof(t *testing.T) {
+	t.Run("reset_to_event_id is resolved to a pointer on the request", func(t *testing.T) {
+		a, compStore := newTestUniversalAPI(t)
+		compStore.AddWorkflow("dapr", &fakeWorkflowComponent{
+			resetFn: func(ctx context.Context, req *workflows.ResetRequest) (*workflows.ResetResponse, error) {
+				require.NotNil(t, req.ResetToEventID)
+				require.Equal(t, int64(42), *req.ResetToEventID)
+				require.Nil(t, req.ResetToTimestamp)
+				return &workflows.ResetResponse{NewInstanceID: "instance1"}, nil
+			},
+		})
+
+		res, err := a.ResetWorkflowBeta1(context.Background(), &runtimev1pb.ResetWorkflowRequest{
+			InstanceId:        "instance1",
+			WorkflowComponent: "dapr",
+			ResetPoint:        &runtimev1pb.ResetWorkflowRequest_ResetToEventId{ResetToEventId: 42},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "instance1", res.NewInstanceId)
+	})
+
+	t.Run("reset_to_timestamp is resolved to a pointer on the request", func(t *testing.T) {
+		a, compStore := newTestUniversalAPI(t)
+		ts := time.Now()
+		compStore.AddWorkflow("dapr", &fakeWorkflowComponent{
+			resetFn: func(ctx context.Context, req *workflows.ResetRequest) (*workflows.ResetResponse, error) {
+				require.Nil(t, req.ResetToEventID)
+				require.NotNil(t, req.ResetToTimestamp)
+				require.WithinDuration(t, ts, *req.ResetToTimestamp, time.Second)
+				return &workflows.ResetResponse{NewInstanceID: "instance1"}, nil
+			},
+		})
+
+		_, err := a.ResetWorkflowBeta1(context.Background(), &runtimev1pb.ResetWorkflowRequest{
+			InstanceId:        "instance1",
+			WorkflowComponent: "dapr",
+			ResetPoint:        &runtimev1pb.ResetWorkflowRequest_ResetToTimestamp{ResetToTimestamp: timestamppb.New(ts)},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("neither reset point set is rejected before reaching the component", func(t *testing.T) {
+		a, compStore := newTestUniversalAPI(t)
+		compStore.AddWorkflow("dapr", &fakeWorkflowComponent{
+			resetFn: func(ctx context.Context, req *workflows.ResetRequest) (*workflows.ResetResponse, error) {
+				t.Fatal("Reset should not be called when no reset point is set")
+				return nil, nil
+			},
+		})
+
+		_, err := a.ResetWorkflowBeta1(context.Background(), &runtimev1pb.ResetWorkflowRequest{
+			InstanceId:        "instance1",
+			WorkflowComponent: "dapr",
+		})
+		require.Equal(t, messages.ErrInvalidResetPoint.WithFormat("instance1").Error(), err.Error())
+	})
+}